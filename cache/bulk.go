@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MSetEntry is one entry passed to MSet: a value together with its own TTL
+// override, with the same zero-means-default-TTL semantics as Set.
+type MSetEntry[V any] struct {
+	Value V
+	TTL   time.Duration
+}
+
+// sortKeys returns a copy of keys in a deterministic order. Callers touching
+// multiple keys under one lock acquisition sort first so that two callers
+// issuing overlapping key sets always take them in the same order,
+// eliminating the lock-ordering deadlocks that plague per-key locking.
+func sortKeys[K comparable](keys []K) []K {
+	sorted := make([]K, len(keys))
+	copy(sorted, keys)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return fmt.Sprint(sorted[i]) < fmt.Sprint(sorted[j])
+	})
+
+	return sorted
+}
+
+// MGet looks up every key in keys under a single lock acquisition, instead
+// of making callers loop Get and re-acquire the lock per key. Missing or
+// expired keys are simply absent from the result; expired entries are
+// evicted as a side effect, same as Get.
+func (c *Cache[K, V]) MGet(keys []K) map[K]V {
+	result := make(map[K]V, len(keys))
+	if len(keys) == 0 {
+		return result
+	}
+
+	type expiredItem struct {
+		key   K
+		value V
+	}
+
+	var expiredItems []expiredItem
+	now := time.Now()
+
+	c.mu.Lock()
+
+	var hits, misses int64
+
+	for _, key := range sortKeys(keys) {
+		item, ok := c.storage[key]
+		if !ok {
+			misses++
+			continue
+		}
+
+		if expired(item.expiresAt, now) {
+			expiredItems = append(expiredItems, expiredItem{key: key, value: item.value})
+			delete(c.storage, key)
+			c.policy.Remove(key)
+			misses++
+			continue
+		}
+
+		if item.ttl > 0 || c.ttl > 0 {
+			if c.ttl > 0 && item.ttl == 0 {
+				item.ttl = c.ttl
+			}
+			item.expiresAt = time.Now().Add(item.ttl)
+		}
+
+		c.policy.Touch(key)
+		result[key] = item.value
+		hits++
+	}
+
+	c.mu.Unlock()
+
+	// Update the aggregate counters directly rather than looping
+	// recordHit/recordMiss per key: that would turn MGet's single lock
+	// acquisition into up to len(keys) recorder calls, defeating the point
+	// of batching. MetricsRecorder.OnHit/OnMiss are not invoked for MGet.
+	c.hits.Add(hits)
+	c.misses.Add(misses)
+
+	for _, e := range expiredItems {
+		c.notifyEvicted(e.key, e.value, EvictionExpired)
+	}
+
+	return result
+}
+
+// MSet inserts or updates every entry in items under a single lock
+// acquisition.
+func (c *Cache[K, V]) MSet(items map[K]MSetEntry[V]) {
+	if len(items) == 0 {
+		return
+	}
+
+	keys := make([]K, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+
+	type evictedItem struct {
+		key   K
+		value V
+	}
+
+	var evicted []evictedItem
+
+	c.mu.Lock()
+
+	for _, key := range sortKeys(keys) {
+		entry := items[key]
+
+		item, ok := c.storage[key]
+		if !ok {
+			if len(c.storage) >= c.size {
+				if victim, victimOk := c.policy.Evict(); victimOk {
+					evicted = append(evicted, evictedItem{key: victim, value: c.storage[victim].value})
+					delete(c.storage, victim)
+				}
+			}
+
+			item = &Item[K, V]{key: key, value: entry.Value, ttl: entry.TTL}
+			c.storage[key] = item
+			c.policy.Add(key)
+		} else {
+			item.value = entry.Value
+			item.ttl = entry.TTL
+			c.policy.Touch(key)
+		}
+
+		if item.ttl > 0 || c.ttl > 0 {
+			if c.ttl > 0 && item.ttl == 0 {
+				item.ttl = c.ttl
+			}
+			item.expiresAt = time.Now().Add(item.ttl)
+		}
+	}
+
+	c.mu.Unlock()
+
+	for _, e := range evicted {
+		c.notifyEvicted(e.key, e.value, EvictionCapacity)
+	}
+}
+
+// MDelete removes every key in keys under a single lock acquisition.
+func (c *Cache[K, V]) MDelete(keys []K) {
+	if len(keys) == 0 {
+		return
+	}
+
+	type deletedItem struct {
+		key   K
+		value V
+	}
+
+	var deleted []deletedItem
+
+	c.mu.Lock()
+
+	for _, key := range sortKeys(keys) {
+		item, ok := c.storage[key]
+		if !ok {
+			continue
+		}
+
+		deleted = append(deleted, deletedItem{key: key, value: item.value})
+		c.policy.Remove(key)
+		delete(c.storage, key)
+	}
+
+	c.mu.Unlock()
+
+	for _, d := range deleted {
+		c.notifyEvicted(d.key, d.value, EvictionManual)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMSetMGetMDeleteRoundTrip(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	c.MSet(map[string]MSetEntry[int]{
+		"a": {Value: 1},
+		"b": {Value: 2},
+		"c": {Value: 3},
+	})
+
+	got := c.MGet([]string{"a", "b", "missing"})
+	if len(got) != 2 || got["a"] != 1 || got["b"] != 2 {
+		t.Fatalf("got %v", got)
+	}
+	if _, ok := got["missing"]; ok {
+		t.Fatalf("want missing key absent from result")
+	}
+
+	c.MDelete([]string{"a", "c"})
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("want \"a\" deleted")
+	}
+	if _, ok := c.Get("c"); ok {
+		t.Fatalf("want \"c\" deleted")
+	}
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Fatalf("want \"b\" untouched, got %d, %v", v, ok)
+	}
+}
+
+func TestMSetEvictsUnderCapacity(t *testing.T) {
+	c := NewCache[string, int](2, time.Hour)
+
+	c.MSet(map[string]MSetEntry[int]{
+		"a": {Value: 1},
+		"b": {Value: 2},
+		"c": {Value: 3},
+	})
+
+	if got := len(c.List()); got != 2 {
+		t.Fatalf("want capacity respected after MSet, got %d entries", got)
+	}
+}
+
+func TestMGetEmptyAndNilKeysAreNoops(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	if got := c.MGet(nil); len(got) != 0 {
+		t.Fatalf("want empty map for nil keys, got %v", got)
+	}
+	if got := c.MGet([]string{}); len(got) != 0 {
+		t.Fatalf("want empty map for empty keys, got %v", got)
+	}
+
+	// Must not panic.
+	c.MSet(map[string]MSetEntry[int]{})
+	c.MDelete(nil)
+}
+
+func TestMGetExpiresStaleEntries(t *testing.T) {
+	c := NewCache[string, int](10, 10*time.Millisecond)
+
+	c.Set("a", 1, 0)
+	time.Sleep(20 * time.Millisecond)
+
+	got := c.MGet([]string{"a"})
+	if _, ok := got["a"]; ok {
+		t.Fatalf("want expired key absent from MGet result, got %v", got)
+	}
+
+	c.mu.RLock()
+	_, stillStored := c.storage["a"]
+	c.mu.RUnlock()
+	if stillStored {
+		t.Fatalf("want MGet to evict the expired entry as a side effect")
+	}
+}
+
+// TestBulkOpsWithOverlappingKeysDontDeadlock guards the deadlock-avoidance
+// rationale for sorting keys before acquiring the lock: two callers issuing
+// the same key set in opposite orders, repeatedly and concurrently, must
+// never hang.
+func TestBulkOpsWithOverlappingKeysDontDeadlock(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	forward := []string{"a", "b", "c", "d", "e"}
+	backward := []string{"e", "d", "c", "b", "a"}
+
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.MGet(forward)
+		}()
+		go func() {
+			defer wg.Done()
+			c.MSet(map[string]MSetEntry[int]{
+				backward[0]: {Value: 1}, backward[1]: {Value: 2}, backward[2]: {Value: 3},
+				backward[3]: {Value: 4}, backward[4]: {Value: 5},
+			})
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("bulk ops with overlapping key sets deadlocked")
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// EvictionReason describes why an item left a Cache, passed to an OnEvicted
+// callback.
+type EvictionReason int
+
+const (
+	// EvictionExpired means the item's TTL elapsed.
+	EvictionExpired EvictionReason = iota
+	// EvictionCapacity means the item was evicted to make room for a new one.
+	EvictionCapacity
+	// EvictionManual means the item was removed via Delete.
+	EvictionManual
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictionExpired:
+		return "expired"
+	case EvictionCapacity:
+		return "capacity"
+	case EvictionManual:
+		return "manual"
+	default:
+		return "unknown"
+	}
+}
+
+// WithCleanupInterval starts a background janitor goroutine that sweeps the
+// Cache for expired items every interval, instead of relying on callers to
+// trigger expiry via Get. Omit or pass <= 0 to disable it.
+func WithCleanupInterval[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.cleanupInterval = interval
+	}
+}
+
+// OnEvicted registers cb to be called whenever an item leaves the cache,
+// whether through expiry, capacity eviction or a manual Delete. cb must not
+// call back into the same Cache; do that from a goroutine instead.
+func (c *Cache[K, V]) OnEvicted(cb func(key K, value V, reason EvictionReason)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onEvicted = cb
+}
+
+func (c *cacheState[K, V]) notifyEvicted(key K, value V, reason EvictionReason) {
+	switch reason {
+	case EvictionExpired:
+		c.expiredEvictions.Add(1)
+	case EvictionCapacity:
+		c.capacityEvictions.Add(1)
+	}
+
+	if c.metrics != nil {
+		c.metrics.OnEviction(reason)
+	}
+
+	c.mu.RLock()
+	cb := c.onEvicted
+	c.mu.RUnlock()
+
+	if cb != nil {
+		cb(key, value, reason)
+	}
+}
+
+// evictExpired removes every item whose TTL has elapsed and notifies
+// OnEvicted for each, with EvictionExpired, once the lock is released.
+func (c *cacheState[K, V]) evictExpired() {
+	now := time.Now()
+
+	type expiredItem struct {
+		key   K
+		value V
+	}
+
+	c.mu.Lock()
+
+	var gone []expiredItem
+
+	for key, item := range c.storage {
+		if expired(item.expiresAt, now) {
+			gone = append(gone, expiredItem{key: key, value: item.value})
+			delete(c.storage, key)
+			c.policy.Remove(key)
+		}
+	}
+
+	c.mu.Unlock()
+
+	for _, e := range gone {
+		c.notifyEvicted(e.key, e.value, EvictionExpired)
+	}
+}
+
+// janitor periodically sweeps a Cache for expired items until stopped.
+type janitor struct {
+	interval time.Duration
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newJanitor(interval time.Duration) *janitor {
+	return &janitor{
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (j *janitor) Stop() {
+	j.stopOnce.Do(func() { close(j.stop) })
+}
+
+// runJanitor takes *cacheState rather than *Cache so the goroutine's own
+// reference doesn't keep the outer Cache reachable; see cacheState's doc
+// comment in main.go for why that matters.
+func runJanitor[K comparable, V any](s *cacheState[K, V], j *janitor) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.evictExpired()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// stopJanitor is registered via runtime.SetFinalizer so a Cache's janitor
+// goroutine exits once the Cache itself becomes unreachable.
+func stopJanitor[K comparable, V any](c *Cache[K, V]) {
+	if c.janitor != nil {
+		c.janitor.Stop()
+	}
+}
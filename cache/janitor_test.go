@@ -0,0 +1,134 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetRemovesExpiredEntry(t *testing.T) {
+	c := NewCache[string, int](10, 10*time.Millisecond)
+
+	c.Set("a", 1, 0)
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("want expired entry to miss")
+	}
+
+	c.mu.RLock()
+	_, stillStored := c.storage["a"]
+	c.mu.RUnlock()
+
+	if stillStored {
+		t.Fatalf("want Get to remove the expired entry from storage, not just report it missing")
+	}
+}
+
+func TestGetExpiryNotifiesOnEvicted(t *testing.T) {
+	c := NewCache[string, int](10, 10*time.Millisecond)
+
+	var mu sync.Mutex
+	var reason EvictionReason
+	var got bool
+
+	c.OnEvicted(func(key string, value int, r EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = true
+		reason = r
+	})
+
+	c.Set("a", 1, 0)
+	time.Sleep(20 * time.Millisecond)
+	c.Get("a")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !got || reason != EvictionExpired {
+		t.Fatalf("want OnEvicted called with EvictionExpired, got called=%v reason=%v", got, reason)
+	}
+}
+
+func TestJanitorSweepsExpiredEntriesProactively(t *testing.T) {
+	c := NewCache[string, int](10, 15*time.Millisecond, WithCleanupInterval[string, int](5*time.Millisecond))
+
+	var mu sync.Mutex
+	var evicted []string
+
+	c.OnEvicted(func(key string, value int, reason EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted = append(evicted, key)
+	})
+
+	c.Set("a", 1, 0)
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(evicted)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("want janitor to evict \"a\" without a Get ever being called, got %v", evicted)
+	}
+}
+
+// TestJanitorGoroutineStopsOnceCacheIsUnreachable guards the whole reason
+// cacheState is split out of Cache: runJanitor must capture the inner
+// cacheState, not the outer Cache, or the Cache's own janitor goroutine
+// would keep it permanently reachable and its finalizer could never run.
+func TestJanitorGoroutineStopsOnceCacheIsUnreachable(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour, WithCleanupInterval[string, int](time.Millisecond))
+	j := c.janitor
+
+	c = nil
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+
+		select {
+		case <-j.stop:
+			return
+		default:
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("want the janitor goroutine to stop once the Cache became unreachable")
+}
+
+func TestDeleteNotifiesOnEvictedWithManualReason(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	var mu sync.Mutex
+	var reason EvictionReason
+	var got bool
+
+	c.OnEvicted(func(key string, value int, r EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = true
+		reason = r
+	})
+
+	c.Set("a", 1, 0)
+	c.Delete("a")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !got || reason != EvictionManual {
+		t.Fatalf("want OnEvicted called with EvictionManual, got called=%v reason=%v", got, reason)
+	}
+}
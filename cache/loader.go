@@ -0,0 +1,126 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// WithNegativeTTL enables negative caching in GetOrLoad: when loader
+// returns an error, the error is remembered for ttl so concurrent or
+// subsequent callers get it back immediately instead of re-running a
+// persistently failing loader. Disabled (the default) when ttl <= 0.
+func WithNegativeTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.negativeTTL = ttl
+	}
+}
+
+// call represents a loader run that is either in flight or just finished;
+// callers coalesced onto it block on wg and then read its result.
+type call[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	ttl   time.Duration
+	err   error
+}
+
+type negativeEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// GetOrLoad returns the cached value for key, loading it via loader on a
+// miss. Concurrent misses for the same key are coalesced: only one caller
+// runs loader while the rest block for its result, the classic cache-aside
+// pattern for values that are expensive to produce and requested by many
+// concurrent callers at once.
+//
+// On success the loaded value is cached with the TTL loader returns,
+// falling back to the cache's default TTL when zero. On failure, if
+// WithNegativeTTL was configured, the error itself is cached for that
+// shorter span so a failing loader does not get stampeded either.
+func (c *Cache[K, V]) GetOrLoad(key K, loader func() (V, time.Duration, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	if err, ok := c.negativeError(key); ok {
+		var zero V
+		return zero, err
+	}
+
+	c.inflightMu.Lock()
+
+	if cl, ok := c.inflight[key]; ok {
+		c.inflightMu.Unlock()
+		cl.wg.Wait()
+		return cl.value, cl.err
+	}
+
+	cl := &call[V]{}
+	cl.wg.Add(1)
+
+	if c.inflight == nil {
+		c.inflight = make(map[K]*call[V])
+	}
+	c.inflight[key] = cl
+
+	c.inflightMu.Unlock()
+
+	start := time.Now()
+	value, ttl, err := loader()
+	c.recordLoad(time.Since(start), err)
+
+	cl.value, cl.ttl, cl.err = value, ttl, err
+
+	// Write the result back to the cache (or negative cache) before
+	// dropping the in-flight entry and releasing waiters: otherwise a new
+	// caller could slip in between the delete and the Set/setNegative,
+	// find neither a cache entry nor an in-flight call, and start a second
+	// concurrent loader run.
+	if err != nil {
+		c.setNegative(key, err)
+	} else {
+		c.Set(key, value, ttl)
+	}
+
+	c.inflightMu.Lock()
+	delete(c.inflight, key)
+	c.inflightMu.Unlock()
+
+	cl.wg.Done()
+
+	return value, err
+}
+
+func (c *Cache[K, V]) negativeError(key K) (error, bool) {
+	c.negativesMu.Lock()
+	defer c.negativesMu.Unlock()
+
+	entry, ok := c.negatives[key]
+	if !ok {
+		return nil, false
+	}
+
+	if entry.expiresAt.Before(time.Now()) {
+		delete(c.negatives, key)
+		return nil, false
+	}
+
+	return entry.err, true
+}
+
+func (c *Cache[K, V]) setNegative(key K, err error) {
+	if c.negativeTTL <= 0 {
+		return
+	}
+
+	c.negativesMu.Lock()
+	defer c.negativesMu.Unlock()
+
+	if c.negatives == nil {
+		c.negatives = make(map[K]negativeEntry)
+	}
+
+	c.negatives[key] = negativeEntry{err: err, expiresAt: time.Now().Add(c.negativeTTL)}
+}
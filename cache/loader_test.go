@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoadCachesZeroTTLForever(t *testing.T) {
+	c := NewCache[string, int](10, 0) // no cache-level default TTL either
+
+	var calls int32
+	loader := func() (int, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		return 7, 0, nil // no per-item TTL
+	}
+
+	v1, err := c.GetOrLoad("k", loader)
+	if err != nil || v1 != 7 {
+		t.Fatalf("first load: v=%d err=%v", v1, err)
+	}
+
+	v2, err := c.GetOrLoad("k", loader)
+	if err != nil || v2 != 7 {
+		t.Fatalf("second load: v=%d err=%v", v2, err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("want loader invoked once for a zero-TTL value, got %d", got)
+	}
+}
+
+func TestGetOrLoadCoalescesConcurrentMisses(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	var calls int32
+	var wg sync.WaitGroup
+	results := make([]int, 50)
+
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad("k", func() (int, time.Duration, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return 42, time.Hour, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = v
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("want loader invoked exactly once, got %d", got)
+	}
+	for i, r := range results {
+		if r != 42 {
+			t.Fatalf("result[%d] = %d, want 42", i, r)
+		}
+	}
+}
+
+func TestGetOrLoadNoStampedeAcrossWriteBackWindow(t *testing.T) {
+	// Regression test: a caller arriving right as the first loader finishes
+	// must see either the freshly cached value or the still-in-flight call,
+	// never a window where both look absent.
+	c := NewCache[string, int](10, time.Hour)
+
+	var calls int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.GetOrLoad("k", func() (int, time.Duration, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(time.Millisecond)
+				return 1, time.Hour, nil
+			})
+		}()
+		if i%20 == 0 {
+			time.Sleep(500 * time.Microsecond)
+		}
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("want loader invoked exactly once despite staggered arrivals, got %d", got)
+	}
+}
+
+func TestGetOrLoadNegativeCachingSuppressesRepeatedFailures(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour, WithNegativeTTL[string, int](50*time.Millisecond))
+
+	var calls int32
+	boom := errors.New("boom")
+	loader := func() (int, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, 0, boom
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetOrLoad("k", loader); !errors.Is(err, boom) {
+			t.Fatalf("call %d: want boom, got %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("want loader invoked once while negative cache is warm, got %d", got)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := c.GetOrLoad("k", loader); !errors.Is(err, boom) {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("want loader re-run after negative TTL expiry, got %d", got)
+	}
+}
+
+func TestGetOrLoadWithoutNegativeTTLRetriesEveryMiss(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour) // negative caching disabled
+
+	var calls int32
+	boom := errors.New("boom")
+	loader := func() (int, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, 0, boom
+	}
+
+	c.GetOrLoad("k", loader)
+	c.GetOrLoad("k", loader)
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("want loader re-run on every miss without negative caching, got %d", got)
+	}
+}
@@ -1,71 +1,136 @@
 package main
 
 import (
-	"container/list"
 	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const DefaultTTL time.Duration = 0
 
-type Cache struct {
-	size    int
-	ttl     time.Duration
-	storage map[string]*list.Element
-	lru     *list.List
-	mu      sync.RWMutex
+// expired reports whether expiresAt has passed. A zero expiresAt means the
+// item was stored with no TTL (neither a per-item nor a cache-level default)
+// and never expires on its own.
+func expired(expiresAt, now time.Time) bool {
+	return !expiresAt.IsZero() && expiresAt.Before(now)
 }
 
-type Item struct {
+// cacheState holds everything a Cache actually operates on. It is split out
+// from Cache itself so the background janitor goroutine (see
+// WithCleanupInterval) can hold a reference to the state it sweeps without
+// keeping the outer Cache reachable: if runJanitor captured *Cache directly,
+// the goroutine's own reference would mean runtime.SetFinalizer on Cache
+// could never fire, and the janitor would outlive every caller that dropped
+// their Cache. Capturing *cacheState instead lets the outer Cache become
+// unreachable (and get finalized) while the janitor keeps sweeping the state
+// it was given, right up until that finalizer stops it.
+type cacheState[K comparable, V any] struct {
+	size            int
+	ttl             time.Duration
+	cleanupInterval time.Duration
+	negativeTTL     time.Duration
+	storage         map[K]*Item[K, V]
+	policy          Policy[K]
+	janitor         *janitor
+	onEvicted       func(key K, value V, reason EvictionReason)
+	mu              sync.RWMutex
+
+	inflightMu sync.Mutex
+	inflight   map[K]*call[V]
+
+	negativesMu sync.Mutex
+	negatives   map[K]negativeEntry
+
+	metrics MetricsRecorder
+
+	hits              atomic.Int64
+	misses            atomic.Int64
+	expiredEvictions  atomic.Int64
+	capacityEvictions atomic.Int64
+	loadCount         atomic.Int64
+	loadLatencyTotal  atomic.Int64
+}
+
+// Cache is a fixed-size, TTL-aware cache. Which key gets evicted once the
+// cache is full is delegated to a Policy (LRU by default); see WithPolicy.
+type Cache[K comparable, V any] struct {
+	*cacheState[K, V]
+}
+
+type Item[K comparable, V any] struct {
 	ttl       time.Duration
-	key       string
-	value     any
+	key       K
+	value     V
 	expiresAt time.Time
 }
 
-func NewCache(size int, ttl time.Duration) *Cache {
-	return &Cache{
+// Option configures a Cache at construction time.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithPolicy selects the eviction Policy a Cache uses once it is full.
+// Defaults to NewLRUPolicy when omitted.
+func WithPolicy[K comparable, V any](factory PolicyFactory[K]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.policy = factory()
+	}
+}
+
+func NewCache[K comparable, V any](size int, ttl time.Duration, opts ...Option[K, V]) *Cache[K, V] {
+	s := &cacheState[K, V]{
 		size:    size,
 		ttl:     ttl,
-		storage: make(map[string]*list.Element, size),
-		lru:     list.New(),
+		storage: make(map[K]*Item[K, V], size),
+	}
+	c := &Cache[K, V]{cacheState: s}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	if s.policy == nil {
+		s.policy = NewLRUPolicy[K]()
+	}
+
+	if s.cleanupInterval > 0 {
+		j := newJanitor(s.cleanupInterval)
+		s.janitor = j
+
+		go runJanitor(s, j)
+
+		runtime.SetFinalizer(c, stopJanitor[K, V])
+	}
+
+	return c
 }
 
-func (c *Cache) Set(key string, value any, ttl time.Duration) {
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	e, ok := c.storage[key]
+	item, ok := c.storage[key]
 
-	var item Item
+	var evictedKey K
+	var evictedValue V
+	evicted := false
 
 	if !ok {
-		if c.lru.Len() >= c.size {
-			old := c.lru.Back()
-			c.lru.Remove(old)
-			delete(c.storage, old.Value.(Item).key)
-		}
-
-		item = Item{
-			key:       key,
-			value:     value,
-			ttl:       ttl,
-			expiresAt: time.Now().Add(ttl),
+		if len(c.storage) >= c.size {
+			if victim, victimOk := c.policy.Evict(); victimOk {
+				evictedKey = victim
+				evictedValue = c.storage[victim].value
+				evicted = true
+				delete(c.storage, victim)
+			}
 		}
 
-		e = c.lru.PushFront(item)
-
-		c.storage[key] = e
-
+		item = &Item[K, V]{key: key, value: value, ttl: ttl}
+		c.storage[key] = item
+		c.policy.Add(key)
 	} else {
-		item = e.Value.(Item)
-
 		item.value = value
 		item.ttl = ttl
-
-		c.lru.MoveToFront(e)
+		c.policy.Touch(key)
 	}
 
 	if item.ttl > 0 || c.ttl > 0 {
@@ -75,20 +140,35 @@ func (c *Cache) Set(key string, value any, ttl time.Duration) {
 		item.expiresAt = time.Now().Add(item.ttl)
 	}
 
-	e.Value = item
+	c.mu.Unlock()
+
+	if evicted {
+		c.notifyEvicted(evictedKey, evictedValue, EvictionCapacity)
+	}
 }
 
-func (c *Cache) Get(key string) (value any, success bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+func (c *Cache[K, V]) Get(key K) (value V, success bool) {
+	c.mu.Lock()
 
-	e, ok := c.storage[key]
+	item, ok := c.storage[key]
 
-	if !ok || (ok && e.Value.(Item).expiresAt.Before(time.Now())) {
-		return nil, false
+	if !ok {
+		c.mu.Unlock()
+		c.recordMiss()
+		return value, false
 	}
 
-	item := e.Value.(Item)
+	if expired(item.expiresAt, time.Now()) {
+		expiredValue := item.value
+		delete(c.storage, key)
+		c.policy.Remove(key)
+		c.mu.Unlock()
+
+		c.recordMiss()
+		c.notifyEvicted(key, expiredValue, EvictionExpired)
+
+		return value, false
+	}
 
 	if item.ttl > 0 || c.ttl > 0 {
 		if c.ttl > 0 && item.ttl == 0 {
@@ -97,20 +177,31 @@ func (c *Cache) Get(key string) (value any, success bool) {
 		item.expiresAt = time.Now().Add(item.ttl)
 	}
 
-	e.Value = item
-	c.lru.MoveToFront(e)
+	c.policy.Touch(key)
+	value = item.value
+
+	c.mu.Unlock()
 
-	return item.value, ok
+	c.recordHit()
+
+	return value, true
 }
 
-func (c *Cache) Delete(key string) {
+func (c *Cache[K, V]) Delete(key K) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	if e, ok := c.storage[key]; ok {
-		c.lru.Remove(e)
-		delete(c.storage, key)
+	item, ok := c.storage[key]
+	if !ok {
+		c.mu.Unlock()
+		return
 	}
+
+	c.policy.Remove(key)
+	delete(c.storage, key)
+
+	c.mu.Unlock()
+
+	c.notifyEvicted(key, item.value, EvictionManual)
 }
 
 func main() {
@@ -118,7 +209,7 @@ func main() {
 }
 
 func mainRace() {
-	cache := NewCache(3, 10*time.Second)
+	cache := NewCache[string, any](3, 10*time.Second)
 	wg := sync.WaitGroup{}
 
 	cache.Set("name", "Alex", DefaultTTL)
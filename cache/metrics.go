@@ -0,0 +1,81 @@
+package main
+
+import "time"
+
+// Stats is a point-in-time snapshot of a Cache's usage counters.
+type Stats struct {
+	Hits               int64
+	Misses             int64
+	ExpiredEvictions   int64
+	CapacityEvictions  int64
+	Size               int
+	AverageLoadLatency time.Duration
+}
+
+// MetricsRecorder lets callers bridge a Cache's internal events to an
+// external metrics system, e.g. Prometheus counters and histograms. Register
+// one via WithMetricsRecorder.
+type MetricsRecorder interface {
+	OnHit()
+	OnMiss()
+	OnEviction(reason EvictionReason)
+	OnLoad(latency time.Duration, err error)
+}
+
+// WithMetricsRecorder registers r to be called on every hit, miss, eviction
+// and GetOrLoad loader run.
+func WithMetricsRecorder[K comparable, V any](r MetricsRecorder) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.metrics = r
+	}
+}
+
+func (c *Cache[K, V]) recordHit() {
+	c.hits.Add(1)
+
+	if c.metrics != nil {
+		c.metrics.OnHit()
+	}
+}
+
+func (c *Cache[K, V]) recordMiss() {
+	c.misses.Add(1)
+
+	if c.metrics != nil {
+		c.metrics.OnMiss()
+	}
+}
+
+func (c *Cache[K, V]) recordLoad(latency time.Duration, err error) {
+	c.loadCount.Add(1)
+	c.loadLatencyTotal.Add(int64(latency))
+
+	if c.metrics != nil {
+		c.metrics.OnLoad(latency, err)
+	}
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction counters,
+// current size and average GetOrLoad latency. The counters themselves are
+// atomic.Int64 fields updated outside the main mutex's critical section, so
+// recording a hit, miss, eviction or load never contends with Get/Set/
+// Delete; reading the current size here briefly takes the read lock.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	size := len(c.storage)
+	c.mu.RUnlock()
+
+	var avgLoadLatency time.Duration
+	if count := c.loadCount.Load(); count > 0 {
+		avgLoadLatency = time.Duration(c.loadLatencyTotal.Load() / count)
+	}
+
+	return Stats{
+		Hits:               c.hits.Load(),
+		Misses:             c.misses.Load(),
+		ExpiredEvictions:   c.expiredEvictions.Load(),
+		CapacityEvictions:  c.capacityEvictions.Load(),
+		Size:               size,
+		AverageLoadLatency: avgLoadLatency,
+	}
+}
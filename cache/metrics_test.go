@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu        sync.Mutex
+	hits      int
+	misses    int
+	evictions []EvictionReason
+	loads     int
+}
+
+func (r *recordingMetrics) OnHit() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hits++
+}
+
+func (r *recordingMetrics) OnMiss() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.misses++
+}
+
+func (r *recordingMetrics) OnEviction(reason EvictionReason) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.evictions = append(r.evictions, reason)
+}
+
+func (r *recordingMetrics) OnLoad(latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loads++
+}
+
+func TestStatsTracksHitsMissesAndCapacityEvictions(t *testing.T) {
+	c := NewCache[string, int](1, time.Hour)
+
+	c.Set("a", 1, 0)
+	c.Get("a")       // hit
+	c.Get("nope")    // miss
+	c.Set("b", 2, 0) // evicts "a" on capacity
+
+	stats := c.Stats()
+	if stats.Hits != 1 {
+		t.Fatalf("want 1 hit, got %d", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Fatalf("want 1 miss, got %d", stats.Misses)
+	}
+	if stats.CapacityEvictions != 1 {
+		t.Fatalf("want 1 capacity eviction, got %d", stats.CapacityEvictions)
+	}
+	if stats.Size != 1 {
+		t.Fatalf("want size 1, got %d", stats.Size)
+	}
+}
+
+func TestStatsTracksExpiredEvictions(t *testing.T) {
+	c := NewCache[string, int](10, 10*time.Millisecond)
+
+	c.Set("a", 1, 0)
+	time.Sleep(20 * time.Millisecond)
+	c.Get("a") // triggers expired eviction as a side effect
+
+	if got := c.Stats().ExpiredEvictions; got != 1 {
+		t.Fatalf("want 1 expired eviction, got %d", got)
+	}
+}
+
+func TestStatsAverageLoadLatency(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	c.GetOrLoad("k", func() (int, time.Duration, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 1, time.Hour, nil
+	})
+
+	if got := c.Stats().AverageLoadLatency; got < 10*time.Millisecond {
+		t.Fatalf("want average load latency >= 10ms, got %v", got)
+	}
+}
+
+func TestMetricsRecorderReceivesHitsMissesAndEvictions(t *testing.T) {
+	rec := &recordingMetrics{}
+	c := NewCache[string, int](1, time.Hour, WithMetricsRecorder[string, int](rec))
+
+	c.Set("a", 1, 0)
+	c.Get("a")
+	c.Get("nope")
+	c.Set("b", 2, 0) // capacity eviction of "a"
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.hits != 1 || rec.misses != 1 {
+		t.Fatalf("want hits=1 misses=1, got hits=%d misses=%d", rec.hits, rec.misses)
+	}
+	if len(rec.evictions) != 1 || rec.evictions[0] != EvictionCapacity {
+		t.Fatalf("want one EvictionCapacity event, got %v", rec.evictions)
+	}
+}
+
+func TestMetricsRecorderReceivesLoadEvents(t *testing.T) {
+	rec := &recordingMetrics{}
+	c := NewCache[string, int](10, time.Hour, WithMetricsRecorder[string, int](rec))
+
+	c.GetOrLoad("k", func() (int, time.Duration, error) {
+		return 1, time.Hour, nil
+	})
+	c.GetOrLoad("k2", func() (int, time.Duration, error) {
+		return 0, 0, errors.New("boom")
+	})
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.loads != 2 {
+		t.Fatalf("want 2 load events (success and failure both recorded), got %d", rec.loads)
+	}
+}
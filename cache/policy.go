@@ -0,0 +1,237 @@
+package main
+
+import "container/list"
+
+// Policy decides which key a Cache evicts once it is full and how a key's
+// standing changes as it is added to or accessed from the cache. Cache calls
+// these methods under its own lock, so implementations do not need to be
+// safe for concurrent use on their own.
+type Policy[K comparable] interface {
+	// Add registers a newly inserted key with the policy.
+	Add(key K)
+	// Touch records an access to, or update of, an existing key.
+	Touch(key K)
+	// Remove drops a key from the policy's bookkeeping, e.g. on Delete.
+	Remove(key K)
+	// Evict selects and removes the next key to evict. ok is false if the
+	// policy has nothing left to evict.
+	Evict() (key K, ok bool)
+}
+
+// PolicyFactory builds a fresh Policy for a new Cache. NewLRUPolicy,
+// NewLFUPolicy and NewFIFOPolicy are themselves valid PolicyFactory values.
+type PolicyFactory[K comparable] func() Policy[K]
+
+// lruPolicy evicts the least recently added or accessed key.
+type lruPolicy[K comparable] struct {
+	order  *list.List
+	lookup map[K]*list.Element
+}
+
+// NewLRUPolicy builds an LRU Policy: the least recently touched key is
+// evicted first.
+func NewLRUPolicy[K comparable]() Policy[K] {
+	return &lruPolicy[K]{
+		order:  list.New(),
+		lookup: make(map[K]*list.Element),
+	}
+}
+
+func (p *lruPolicy[K]) Add(key K) {
+	p.lookup[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy[K]) Touch(key K) {
+	if e, ok := p.lookup[key]; ok {
+		p.order.MoveToFront(e)
+	}
+}
+
+func (p *lruPolicy[K]) Remove(key K) {
+	if e, ok := p.lookup[key]; ok {
+		p.order.Remove(e)
+		delete(p.lookup, key)
+	}
+}
+
+func (p *lruPolicy[K]) Evict() (key K, ok bool) {
+	e := p.order.Back()
+	if e == nil {
+		return key, false
+	}
+
+	p.order.Remove(e)
+	key = e.Value.(K)
+	delete(p.lookup, key)
+
+	return key, true
+}
+
+// fifoPolicy evicts keys in the order they were first added, ignoring
+// subsequent accesses.
+type fifoPolicy[K comparable] struct {
+	order  *list.List
+	lookup map[K]*list.Element
+}
+
+// NewFIFOPolicy builds a FIFO Policy: keys are evicted in insertion order
+// regardless of how often they are accessed.
+func NewFIFOPolicy[K comparable]() Policy[K] {
+	return &fifoPolicy[K]{
+		order:  list.New(),
+		lookup: make(map[K]*list.Element),
+	}
+}
+
+func (p *fifoPolicy[K]) Add(key K) {
+	p.lookup[key] = p.order.PushFront(key)
+}
+
+// Touch is a no-op: FIFO ignores access recency entirely.
+func (p *fifoPolicy[K]) Touch(key K) {}
+
+func (p *fifoPolicy[K]) Remove(key K) {
+	if e, ok := p.lookup[key]; ok {
+		p.order.Remove(e)
+		delete(p.lookup, key)
+	}
+}
+
+func (p *fifoPolicy[K]) Evict() (key K, ok bool) {
+	e := p.order.Back()
+	if e == nil {
+		return key, false
+	}
+
+	p.order.Remove(e)
+	key = e.Value.(K)
+	delete(p.lookup, key)
+
+	return key, true
+}
+
+// lfuFreqNode groups every key that currently shares the same access
+// frequency, so that bumping a key's frequency or evicting the least
+// frequently used key is O(1).
+type lfuFreqNode[K comparable] struct {
+	freq  int
+	order *list.List // list.Element.Value is K, most recent at the front
+}
+
+// lfuItemLoc pins down exactly where a key lives in the frequency list: which
+// frequency node it belongs to, and its element within that node's list.
+type lfuItemLoc[K comparable] struct {
+	freqElem *list.Element // element in lfuPolicy.freqs, Value is *lfuFreqNode[K]
+	itemElem *list.Element // element in that node's order list, Value is K
+}
+
+// lfuPolicy evicts the least frequently used key, breaking ties by recency
+// within the tied frequency, using the classic O(1) frequency-list
+// algorithm: a doubly linked list of frequency nodes, each holding its own
+// doubly linked list of keys sharing that frequency.
+type lfuPolicy[K comparable] struct {
+	freqs     *list.List // ascending by freq, Value is *lfuFreqNode[K]
+	freqIndex map[int]*list.Element
+	items     map[K]*lfuItemLoc[K]
+}
+
+// NewLFUPolicy builds an LFU Policy: the least frequently accessed key is
+// evicted first.
+func NewLFUPolicy[K comparable]() Policy[K] {
+	return &lfuPolicy[K]{
+		freqs:     list.New(),
+		freqIndex: make(map[int]*list.Element),
+		items:     make(map[K]*lfuItemLoc[K]),
+	}
+}
+
+// nodeForFreq returns the node for freq, creating and linking it in if
+// missing. A new node is inserted right after `after`, or at the front of
+// the list (the lowest frequency) when after is nil.
+func (p *lfuPolicy[K]) nodeForFreq(freq int, after *list.Element) *lfuFreqNode[K] {
+	if e, ok := p.freqIndex[freq]; ok {
+		return e.Value.(*lfuFreqNode[K])
+	}
+
+	node := &lfuFreqNode[K]{freq: freq, order: list.New()}
+
+	var e *list.Element
+	if after == nil {
+		e = p.freqs.PushFront(node)
+	} else {
+		e = p.freqs.InsertAfter(node, after)
+	}
+
+	p.freqIndex[freq] = e
+
+	return node
+}
+
+func (p *lfuPolicy[K]) Add(key K) {
+	node := p.nodeForFreq(1, nil)
+	itemElem := node.order.PushFront(key)
+
+	p.items[key] = &lfuItemLoc[K]{
+		freqElem: p.freqIndex[1],
+		itemElem: itemElem,
+	}
+}
+
+func (p *lfuPolicy[K]) Touch(key K) {
+	loc, ok := p.items[key]
+	if !ok {
+		return
+	}
+
+	oldElem := loc.freqElem
+	oldNode := oldElem.Value.(*lfuFreqNode[K])
+	newFreq := oldNode.freq + 1
+
+	oldNode.order.Remove(loc.itemElem)
+
+	newNode := p.nodeForFreq(newFreq, oldElem)
+	loc.itemElem = newNode.order.PushFront(key)
+	loc.freqElem = p.freqIndex[newFreq]
+
+	if oldNode.order.Len() == 0 {
+		p.freqs.Remove(oldElem)
+		delete(p.freqIndex, oldNode.freq)
+	}
+}
+
+func (p *lfuPolicy[K]) Remove(key K) {
+	loc, ok := p.items[key]
+	if !ok {
+		return
+	}
+
+	node := loc.freqElem.Value.(*lfuFreqNode[K])
+	node.order.Remove(loc.itemElem)
+	delete(p.items, key)
+
+	if node.order.Len() == 0 {
+		p.freqs.Remove(loc.freqElem)
+		delete(p.freqIndex, node.freq)
+	}
+}
+
+func (p *lfuPolicy[K]) Evict() (key K, ok bool) {
+	e := p.freqs.Front()
+	if e == nil {
+		return key, false
+	}
+
+	node := e.Value.(*lfuFreqNode[K])
+	back := node.order.Back()
+	key = back.Value.(K)
+
+	node.order.Remove(back)
+	delete(p.items, key)
+
+	if node.order.Len() == 0 {
+		p.freqs.Remove(e)
+		delete(p.freqIndex, node.freq)
+	}
+
+	return key, true
+}
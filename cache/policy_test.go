@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheLRUEviction(t *testing.T) {
+	c := NewCache[string, int](2, time.Hour) // LRU is the default policy
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Get("a") // "a" is now the most recently used
+	c.Set("c", 3, 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("want least recently used key \"b\" evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("want \"a\" to survive, got %d, %v", v, ok)
+	}
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Fatalf("want \"c\" present, got %d, %v", v, ok)
+	}
+}
+
+func TestCacheFIFOEviction(t *testing.T) {
+	c := NewCache[string, int](2, time.Hour, WithPolicy[string, int](NewFIFOPolicy[string]))
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Get("a") // FIFO ignores access recency, unlike LRU
+	c.Set("c", 3, 0)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("want first-inserted key \"a\" evicted regardless of access")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("want \"b\" to survive")
+	}
+}
+
+func TestCacheLFUEviction(t *testing.T) {
+	c := NewCache[string, int](2, time.Hour, WithPolicy[string, int](NewLFUPolicy[string]))
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Get("a")
+	c.Get("a") // "a" now has frequency 3, "b" stays at 1
+	c.Set("c", 3, 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("want least frequently used key \"b\" evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("want \"a\" to survive, got %d, %v", v, ok)
+	}
+}
+
+func TestLFUPolicyTieBreaksByRecencyWithinFrequency(t *testing.T) {
+	p := NewLFUPolicy[string]()
+
+	p.Add("a")
+	p.Add("b")
+	p.Add("c") // all at frequency 1; "a" is the oldest, "c" the newest
+
+	victim, ok := p.Evict()
+	if !ok || victim != "a" {
+		t.Fatalf("want oldest same-frequency key \"a\" evicted, got %q, %v", victim, ok)
+	}
+}
+
+func TestLFUPolicyRemoveDropsEmptyFrequencyNode(t *testing.T) {
+	p := NewLFUPolicy[string]().(*lfuPolicy[string])
+
+	p.Add("a")
+	p.Remove("a")
+
+	if p.freqs.Len() != 0 {
+		t.Fatalf("want empty frequency node cleaned up, freqs.Len() = %d", p.freqs.Len())
+	}
+	if len(p.freqIndex) != 0 {
+		t.Fatalf("want freqIndex cleared, got %v", p.freqIndex)
+	}
+}
+
+func TestCachePolicyEvictOnEmptyCacheIsNoop(t *testing.T) {
+	c := NewCache[string, int](0, time.Hour)
+
+	// A zero-capacity cache should not panic when Set tries to evict from
+	// an empty policy.
+	c.Set("a", 1, 0)
+}
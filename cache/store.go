@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Store is the interface implemented by cache backends: a fixed-size,
+// TTL-aware key/value store. Cache is the default, LRU-by-default
+// implementation; see WithPolicy to plug in another eviction Policy.
+type Store[K comparable, V any] interface {
+	Get(key K) (value V, ok bool)
+	Set(key K, value V, ttl time.Duration)
+	Delete(key K)
+	List() []V
+	Resize(size int)
+}
+
+var _ Store[string, any] = (*Cache[string, any])(nil)
+
+// List returns every non-expired value currently in the cache, in no
+// particular order.
+func (c *Cache[K, V]) List() []V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now()
+	values := make([]V, 0, len(c.storage))
+
+	for _, item := range c.storage {
+		if expired(item.expiresAt, now) {
+			continue
+		}
+		values = append(values, item.value)
+	}
+
+	return values
+}
+
+// Resize changes the cache's capacity. If size is smaller than the current
+// number of entries, the policy's normal eviction order picks the excess to
+// drop, notifying OnEvicted with EvictionCapacity for each.
+func (c *Cache[K, V]) Resize(size int) {
+	c.mu.Lock()
+
+	c.size = size
+
+	type evictedItem struct {
+		key   K
+		value V
+	}
+
+	var evicted []evictedItem
+
+	for len(c.storage) > c.size {
+		victim, ok := c.policy.Evict()
+		if !ok {
+			break
+		}
+
+		item, ok := c.storage[victim]
+		if !ok {
+			continue
+		}
+
+		evicted = append(evicted, evictedItem{key: victim, value: item.value})
+		delete(c.storage, victim)
+	}
+
+	c.mu.Unlock()
+
+	for _, e := range evicted {
+		c.notifyEvicted(e.key, e.value, EvictionCapacity)
+	}
+}
+
+// KeyFunc derives the string key a higher-level cache of arbitrary objects
+// should store obj under, e.g. a namespace/name composite.
+type KeyFunc func(obj any) (string, error)
+
+// ObjectStore layers a KeyFunc over a Store[string, any] so callers can
+// cache typed objects by their own notion of identity instead of
+// stringifying keys themselves. Multiple ObjectStores can share one backend
+// Store, or each can own its own, giving isolated caches per tenant or
+// resource kind.
+type ObjectStore struct {
+	store   Store[string, any]
+	keyFunc KeyFunc
+}
+
+func NewObjectStore(store Store[string, any], keyFunc KeyFunc) *ObjectStore {
+	return &ObjectStore{store: store, keyFunc: keyFunc}
+}
+
+// GetByKey looks up an object directly by its store key, bypassing KeyFunc.
+func (s *ObjectStore) GetByKey(key string) (value any, ok bool) {
+	return s.store.Get(key)
+}
+
+// Add derives obj's key via KeyFunc and inserts it with the store's default
+// TTL.
+func (s *ObjectStore) Add(obj any) error {
+	key, err := s.keyFunc(obj)
+	if err != nil {
+		return fmt.Errorf("deriving key: %w", err)
+	}
+
+	s.store.Set(key, obj, DefaultTTL)
+
+	return nil
+}
+
+// Update replaces the object stored under obj's derived key. It is
+// equivalent to Add: both just Set under the derived key.
+func (s *ObjectStore) Update(obj any) error {
+	return s.Add(obj)
+}
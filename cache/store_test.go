@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCacheList(t *testing.T) {
+	c := NewCache[string, int](10, time.Hour)
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+
+	values := c.List()
+	if len(values) != 2 {
+		t.Fatalf("want 2 values, got %v", values)
+	}
+
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	if sum != 3 {
+		t.Fatalf("want values summing to 3, got %v", values)
+	}
+}
+
+func TestCacheListIncludesEntriesWithNoTTLAtAll(t *testing.T) {
+	c := NewCache[string, int](10, 0) // no cache-level default TTL
+
+	c.Set("a", 1, 0)         // no per-item TTL either: never expires
+	c.Set("b", 2, time.Hour) // explicit per-item TTL
+
+	values := c.List()
+	if len(values) != 2 {
+		t.Fatalf("want both entries listed, got %v", values)
+	}
+}
+
+func TestCacheListExcludesExpired(t *testing.T) {
+	c := NewCache[string, int](10, 10*time.Millisecond)
+
+	c.Set("a", 1, 0)
+	time.Sleep(20 * time.Millisecond)
+
+	if values := c.List(); len(values) != 0 {
+		t.Fatalf("want expired entry excluded from List, got %v", values)
+	}
+}
+
+func TestCacheResizeShrinksAndEvictsExcess(t *testing.T) {
+	c := NewCache[string, int](5, time.Hour)
+
+	for i := 0; i < 5; i++ {
+		c.Set(string(rune('a'+i)), i, 0)
+	}
+
+	c.Resize(2)
+
+	if got := len(c.List()); got != 2 {
+		t.Fatalf("want 2 entries remaining after Resize(2), got %d", got)
+	}
+
+	// Growing back shouldn't evict what's left, and new entries should fit.
+	c.Resize(5)
+	c.Set("z", 99, 0)
+	if got := len(c.List()); got != 3 {
+		t.Fatalf("want 3 entries after growing and inserting, got %d", got)
+	}
+}
+
+func TestCacheResizeNotifiesCapacityEviction(t *testing.T) {
+	c := NewCache[string, int](3, time.Hour)
+
+	var evicted []string
+	c.OnEvicted(func(key string, value int, reason EvictionReason) {
+		if reason == EvictionCapacity {
+			evicted = append(evicted, key)
+		}
+	})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Set("c", 3, 0)
+	c.Resize(1)
+
+	if len(evicted) != 2 {
+		t.Fatalf("want 2 capacity evictions from shrinking to size 1, got %v", evicted)
+	}
+}
+
+func TestObjectStoreAddGetByKeyUpdate(t *testing.T) {
+	type widget struct {
+		Namespace, Name string
+		Replicas        int
+	}
+
+	backing := NewCache[string, any](10, time.Hour)
+	store := NewObjectStore(backing, func(obj any) (string, error) {
+		w, ok := obj.(widget)
+		if !ok {
+			return "", errors.New("not a widget")
+		}
+		return w.Namespace + "/" + w.Name, nil
+	})
+
+	if err := store.Add(widget{Namespace: "prod", Name: "api", Replicas: 3}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, ok := store.GetByKey("prod/api")
+	if !ok {
+		t.Fatalf("want prod/api present")
+	}
+	if got.(widget).Replicas != 3 {
+		t.Fatalf("got %+v", got)
+	}
+
+	if err := store.Update(widget{Namespace: "prod", Name: "api", Replicas: 5}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, _ = store.GetByKey("prod/api")
+	if got.(widget).Replicas != 5 {
+		t.Fatalf("want Update to overwrite in place, got %+v", got)
+	}
+}
+
+func TestObjectStoreAddPropagatesKeyFuncError(t *testing.T) {
+	backing := NewCache[string, any](10, time.Hour)
+	keyErr := errors.New("cannot derive key")
+	store := NewObjectStore(backing, func(obj any) (string, error) {
+		return "", keyErr
+	})
+
+	if err := store.Add("anything"); !errors.Is(err, keyErr) {
+		t.Fatalf("want KeyFunc error propagated, got %v", err)
+	}
+}